@@ -4,6 +4,7 @@ import (
 	"errors"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,30 +15,62 @@ var (
 	ErrCacheIsOut  = errors.New("cache is out of date")
 )
 
-type Cache struct {
+// Entry is one cached value together with its bookkeeping, generic over
+// the value type V so callers don't pay interface{} boxing/assertion
+// cost for their own concrete types.
+type Entry[V any] struct {
+	Value      V         `json:"value"`
+	CreatedAt  time.Time `json:"created_at"`
+	Expiration int64     `json:"expiration"` // Актуальность кэша
+}
+
+// Value is the entry type held by Cache, the interface{}-valued,
+// string-keyed instantiation of TypedCache kept for callers that predate
+// generics.
+type Value = Entry[interface{}]
+
+// TypedCache is a generic, TTL-based cache container keyed by K and
+// holding values of type V. Cache is TypedCache[string, interface{}]; use
+// TypedCache directly when the extra type safety of a concrete K/V is
+// worth spelling out the instantiation, e.g. int64 IDs or struct keys.
+type TypedCache[K comparable, V any] struct {
 	// если установлено значение меньше или равно 0 — время жизни кеша бессрочно
 	defaultExpiration time.Duration // продолжительность жизни кеша по-умолчанию
 	// При установленном значении меньше или равно 0 — очистка и удаление просроченного кеша не происходит
 	cleanupInterval time.Duration // интервал, через который запускается механизм очистки кеша
 
-	m     map[string]Value
+	m     map[K]Entry[V]
 	mutex sync.RWMutex
-}
 
-type Value struct {
-	Value      interface{} `json:"value"`
-	CreatedAt  time.Time   `json:"created_at"`
-	Expiration int64       `json:"expiration"` // Актуальность кэша
+	onEvicted func(key K, value V)
+
+	// policy и maxEntries включают режим ограниченного размера кеша;
+	// при policy == PolicyNone кеш остаётся неограниченным, как раньше.
+	policy     CachePolicy
+	maxEntries int
+
+	lru *lruList[K]
+	lfu *lfuList[K]
+
+	loaderMu sync.Mutex
+	loaders  map[K]*call[V]
+
+	stats cacheStats
 }
 
-// NewCache Create a new cache container.
-// it will start gc automatically.
-func NewCache(size int, expiration, cleanupInterval time.Duration) *Cache {
-	newMap := make(map[string]Value, size)
+// Cache is the original interface{}-valued, string-keyed cache. It is a
+// thin instantiation of TypedCache kept so existing callers don't need to
+// migrate to generics.
+type Cache = TypedCache[string, interface{}]
+
+// NewTypedCache creates a cache container keyed by K and holding values
+// of type V. It will start gc automatically.
+func NewTypedCache[K comparable, V any](size int, expiration, cleanupInterval time.Duration) *TypedCache[K, V] {
+	newMap := make(map[K]Entry[V], size)
 
 	defaultSize = size
 
-	cache := &Cache{
+	cache := &TypedCache[K, V]{
 		defaultExpiration: expiration,
 		cleanupInterval:   cleanupInterval,
 		m:                 newMap,
@@ -50,12 +83,30 @@ func NewCache(size int, expiration, cleanupInterval time.Duration) *Cache {
 	return cache
 }
 
-func (c *Cache) StartGC() {
+// NewCache Create a new cache container.
+// it will start gc automatically.
+func NewCache(size int, expiration, cleanupInterval time.Duration) *Cache {
+	return NewTypedCache[string, interface{}](size, expiration, cleanupInterval)
+}
+
+func (c *TypedCache[K, V]) StartGC() {
 	go c.GC()
 }
 
+// OnEvicted registers a callback that is invoked whenever a cached value
+// leaves the cache: on explicit Delete, on expiration during a GC sweep,
+// when Set overwrites an existing key, or when Set evicts an entry to stay
+// within Options.MaxEntries. The callback runs outside the
+// cache's write lock, so it is safe for it to call back into the cache.
+func (c *TypedCache[K, V]) OnEvicted(f func(key K, value V)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.onEvicted = f
+}
+
 // Set cache by key with duration.
-func (c *Cache) Set(key string, value interface{}, duration time.Duration) {
+func (c *TypedCache[K, V]) Set(key K, value V, duration time.Duration) {
 
 	var expiration int64
 
@@ -68,39 +119,106 @@ func (c *Cache) Set(key string, value interface{}, duration time.Duration) {
 	}
 
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
 
-	c.m[key] = Value{
+	old, overwritten := c.m[key]
+
+	var (
+		victimKey     K
+		victimValue   Entry[V]
+		victimEvicted bool
+	)
+
+	if !overwritten && c.maxEntries > 0 && len(c.m) >= c.maxEntries {
+		if vk, ok := c.evictionVictimLocked(); ok {
+			victimKey = vk
+			victimValue = c.m[vk]
+			victimEvicted = true
+
+			c.removeLocked(vk)
+		}
+	}
+
+	c.m[key] = Entry[V]{
 		Value:      value,
 		Expiration: expiration,
 		CreatedAt:  time.Now(),
 	}
-}
 
-// Get get cached value by key.
-func (c *Cache) Get(key string) (value interface{}, err error) {
-	c.mutex.RLock()
+	c.touchLocked(key)
 
-	defer c.mutex.RUnlock()
+	onEvicted := c.onEvicted
 
-	val, ok := c.m[key]
+	c.mutex.Unlock()
+
+	atomic.AddUint64(&c.stats.sets, 1)
+
+	if victimEvicted {
+		atomic.AddUint64(&c.stats.evictions, 1)
+	}
+
+	if onEvicted != nil {
+		if overwritten {
+			onEvicted(key, old.Value)
+		}
+
+		if victimEvicted {
+			onEvicted(victimKey, victimValue.Value)
+		}
+	}
+}
+
+// Get get cached value by key.
+func (c *TypedCache[K, V]) Get(key K) (value V, err error) {
+	val, ok := c.access(key)
 	if !ok {
-		return nil, ErrKeyNotFound
+		atomic.AddUint64(&c.stats.misses, 1)
+		return value, ErrKeyNotFound
 	}
 
 	if val.Expiration > 0 {
 		if time.Now().UnixNano() > val.Expiration {
-			return nil, ErrCacheIsOut
+			atomic.AddUint64(&c.stats.misses, 1)
+			return value, ErrCacheIsOut
 		}
 	}
 
+	atomic.AddUint64(&c.stats.hits, 1)
+
 	return val.Value, nil
 }
 
+// access returns the raw, un-expiration-checked entry stored for key,
+// recording the access with the active eviction policy along the way.
+// It holds the write lock when a policy is active (touchLocked needs it)
+// and the read lock otherwise. Callers are responsible for any
+// expiration check and stats bookkeeping; Get and GetOrRefresh both
+// build on this so a hit/miss is recorded consistently everywhere.
+func (c *TypedCache[K, V]) access(key K) (Entry[V], bool) {
+	if c.policy != PolicyNone {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		val, ok := c.m[key]
+		if ok {
+			c.touchLocked(key)
+		}
+
+		return val, ok
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	val, ok := c.m[key]
+
+	return val, ok
+}
+
 // GetMulti gets caches from memory.
 // if non-existed or expired, return nil.
-func (c *Cache) GetMulti(keys []string) []interface{} {
-	var rc []interface{}
+// For a V that cannot be nil, the zero value of V takes its place.
+func (c *TypedCache[K, V]) GetMulti(keys []K) []V {
+	var rc []V
 
 	for _, key := range keys {
 		v, _ := c.Get(key)
@@ -111,23 +229,33 @@ func (c *Cache) GetMulti(keys []string) []interface{} {
 }
 
 // Delete remove cache by key.
-func (c *Cache) Delete(key string) error {
+func (c *TypedCache[K, V]) Delete(key K) error {
 
 	c.mutex.Lock()
 
-	defer c.mutex.Unlock()
-
-	if _, ok := c.m[key]; !ok {
+	val, ok := c.m[key]
+	if !ok {
+		c.mutex.Unlock()
 		return ErrKeyNotFound
 	}
 
-	delete(c.m, key)
+	c.removeLocked(key)
+
+	onEvicted := c.onEvicted
+
+	c.mutex.Unlock()
+
+	atomic.AddUint64(&c.stats.deletes, 1)
+
+	if onEvicted != nil {
+		onEvicted(key, val.Value)
+	}
 
 	return nil
 }
 
 // Exist check if cached value exists or not.
-func (c *Cache) IsExist(key string) bool {
+func (c *TypedCache[K, V]) IsExist(key K) bool {
 
 	c.mutex.RLock()
 
@@ -140,7 +268,7 @@ func (c *Cache) IsExist(key string) bool {
 
 // Expire check if cached value expired or not.
 // if cache expire == true, cache not expire == false.
-func (c *Cache) Expire(key string) (bool, error) {
+func (c *TypedCache[K, V]) Expire(key K) (bool, error) {
 
 	c.mutex.RLock()
 
@@ -159,18 +287,32 @@ func (c *Cache) Expire(key string) (bool, error) {
 }
 
 // FlushAll clear all cache.
-func (c *Cache) FlushAll() {
-	newMap := make(map[string]Value, defaultSize)
+func (c *TypedCache[K, V]) FlushAll() {
+	c.mutex.Lock()
+
+	c.m = make(map[K]Entry[V], defaultSize)
+
+	switch c.policy {
+	case PolicyLRU:
+		c.lru = newLRUList[K]()
+	case PolicyLFU:
+		c.lfu = newLFUList[K]()
+	}
+
+	c.mutex.Unlock()
 
-	c.m = newMap
 	runtime.GC()
 }
 
-func (c *Cache) GC() {
+func (c *TypedCache[K, V]) GC() {
 	for {
 		<-time.After(c.cleanupInterval)
 
-		if len(c.m) == 0 {
+		c.mutex.RLock()
+		empty := len(c.m) == 0
+		c.mutex.RUnlock()
+
+		if empty {
 			return
 		}
 
@@ -181,7 +323,7 @@ func (c *Cache) GC() {
 }
 
 // expiredKeys returns a list of "expired" keys
-func (c *Cache) expiredKeys() (keys []string) {
+func (c *TypedCache[K, V]) expiredKeys() (keys []K) {
 
 	c.mutex.RLock()
 
@@ -197,13 +339,29 @@ func (c *Cache) expiredKeys() (keys []string) {
 }
 
 // clearItems removes keys from the passed list, in our case "expired"
-func (c *Cache) clearItems(keys []string) {
+func (c *TypedCache[K, V]) clearItems(keys []K) {
 
 	c.mutex.Lock()
 
-	defer c.mutex.Unlock()
+	evicted := make(map[K]V, len(keys))
 
 	for _, k := range keys {
-		delete(c.m, k)
+		if v, ok := c.m[k]; ok {
+			evicted[k] = v.Value
+		}
+
+		c.removeLocked(k)
+	}
+
+	onEvicted := c.onEvicted
+
+	c.mutex.Unlock()
+
+	atomic.AddUint64(&c.stats.expirations, uint64(len(evicted)))
+
+	if onEvicted != nil {
+		for k, v := range evicted {
+			onEvicted(k, v)
+		}
 	}
 }
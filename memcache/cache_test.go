@@ -0,0 +1,62 @@
+package memcache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOnEvictedFiresOnDelete(t *testing.T) {
+	c := NewCache(0, 0, 0)
+	c.Set("k", "v", 0)
+
+	var gotKey string
+	var gotVal interface{}
+
+	c.OnEvicted(func(key string, value interface{}) {
+		gotKey, gotVal = key, value
+	})
+
+	if err := c.Delete("k"); err != nil {
+		t.Fatalf("Delete: unexpected error %v", err)
+	}
+
+	if gotKey != "k" || gotVal != "v" {
+		t.Fatalf("OnEvicted fired with (%q, %v), want (\"k\", \"v\")", gotKey, gotVal)
+	}
+}
+
+func TestOnEvictedFiresOnOverwrite(t *testing.T) {
+	c := NewCache(0, 0, 0)
+	c.Set("k", "old", 0)
+
+	var gotVal interface{}
+
+	c.OnEvicted(func(key string, value interface{}) {
+		gotVal = value
+	})
+
+	c.Set("k", "new", 0)
+
+	if gotVal != "old" {
+		t.Fatalf("OnEvicted fired with %v, want \"old\"", gotVal)
+	}
+}
+
+func TestOnEvictedFiresOnExpiration(t *testing.T) {
+	c := NewCache(0, time.Millisecond, 5*time.Millisecond)
+	c.Set("k", "v", 0)
+
+	done := make(chan struct{})
+	var once sync.Once
+
+	c.OnEvicted(func(key string, value interface{}) {
+		once.Do(func() { close(done) })
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("OnEvicted was not called for an expired entry within 1s")
+	}
+}
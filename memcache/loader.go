@@ -0,0 +1,138 @@
+package memcache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// call represents a single in-flight or completed loader invocation shared
+// by every concurrent GetOrLoad/GetOrRefresh caller for the same key.
+type call[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// startCall registers the caller as the one responsible for running loader
+// for key, or joins an already in-flight call. The second return value
+// reports whether the call was already in flight.
+func (c *TypedCache[K, V]) startCall(key K) (*call[V], bool) {
+	c.loaderMu.Lock()
+	defer c.loaderMu.Unlock()
+
+	if c.loaders == nil {
+		c.loaders = make(map[K]*call[V])
+	}
+
+	if cl, ok := c.loaders[key]; ok {
+		return cl, true
+	}
+
+	cl := &call[V]{}
+	cl.wg.Add(1)
+	c.loaders[key] = cl
+
+	return cl, false
+}
+
+// finishCall removes key's in-flight call and releases any callers blocked
+// on cl.wg.Wait.
+func (c *TypedCache[K, V]) finishCall(key K, cl *call[V]) {
+	c.loaderMu.Lock()
+	delete(c.loaders, key)
+	c.loaderMu.Unlock()
+
+	cl.wg.Done()
+}
+
+// runLoader invokes loader for an in-flight call and records its result
+// on cl. finishCall always runs, even if loader panics, so waiters never
+// block forever and the key never stays wedged in c.loaders. If loader
+// panics, cl.err is set so waiters see a real error instead of a silent
+// zero value, and the panic value is returned so the goroutine that
+// actually ran loader can decide whether to re-panic.
+func (c *TypedCache[K, V]) runLoader(key K, cl *call[V], ttl time.Duration, loader func() (V, error)) (recovered interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			cl.err = fmt.Errorf("memcache: loader panicked: %v", r)
+			recovered = r
+		}
+
+		c.finishCall(key, cl)
+	}()
+
+	cl.val, cl.err = loader()
+	if cl.err == nil {
+		c.Set(key, cl.val, ttl)
+	}
+
+	return nil
+}
+
+// GetOrLoad returns the cached value for key if present and unexpired.
+// Otherwise it calls loader exactly once per key, even under concurrent
+// misses: the first caller runs loader while the rest block and receive
+// its result. A successful load is stored with duration ttl, following
+// the same zero-duration-means-default-expiration rule as Set.
+func (c *TypedCache[K, V]) GetOrLoad(key K, ttl time.Duration, loader func() (V, error)) (V, error) {
+	if v, err := c.Get(key); err == nil {
+		return v, nil
+	}
+
+	cl, shared := c.startCall(key)
+	if shared {
+		cl.wg.Wait()
+		return cl.val, cl.err
+	}
+
+	if r := c.runLoader(key, cl, ttl, loader); r != nil {
+		panic(r)
+	}
+
+	return cl.val, cl.err
+}
+
+// GetOrRefresh implements stale-while-revalidate on top of GetOrLoad: a
+// fresh value is returned immediately; a value that expired less than
+// staleGrace ago is also returned immediately, while loader refreshes it
+// in the background; anything older falls back to GetOrLoad and blocks
+// the caller until the refresh completes. staleGrace <= 0 disables the
+// stale path entirely.
+func (c *TypedCache[K, V]) GetOrRefresh(key K, ttl, staleGrace time.Duration, loader func() (V, error)) (V, error) {
+	val, ok := c.access(key)
+
+	if ok {
+		now := time.Now().UnixNano()
+		fresh := val.Expiration == 0 || now <= val.Expiration
+		stale := !fresh && staleGrace > 0 && now <= val.Expiration+staleGrace.Nanoseconds()
+
+		if fresh || stale {
+			atomic.AddUint64(&c.stats.hits, 1)
+
+			if stale {
+				c.refreshInBackground(key, ttl, loader)
+			}
+
+			return val.Value, nil
+		}
+	}
+
+	return c.GetOrLoad(key, ttl, loader)
+}
+
+// refreshInBackground runs loader for key in a separate goroutine, sharing
+// the same in-flight bookkeeping as GetOrLoad so a concurrent miss joins
+// this refresh instead of starting a second one. A panicking loader is
+// recorded on the call (so anything that joined the refresh still sees
+// an error) but does not propagate further: stale-while-revalidate is an
+// invisible background optimization and must not crash the process.
+func (c *TypedCache[K, V]) refreshInBackground(key K, ttl time.Duration, loader func() (V, error)) {
+	cl, shared := c.startCall(key)
+	if shared {
+		return
+	}
+
+	go c.runLoader(key, cl, ttl, loader)
+}
@@ -0,0 +1,181 @@
+package memcache
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoadCachesResult(t *testing.T) {
+	c := NewCache(0, 0, 0)
+
+	var calls int32
+
+	loader := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "v", nil
+	}
+
+	for i := 0; i < 5; i++ {
+		v, err := c.GetOrLoad("k", time.Minute, loader)
+		if err != nil || v != "v" {
+			t.Fatalf("GetOrLoad = (%v, %v), want (\"v\", nil)", v, err)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("loader called %d times, want 1", calls)
+	}
+}
+
+func TestGetOrLoadConcurrentMissesShareOneCall(t *testing.T) {
+	c := NewCache(0, 0, 0)
+
+	var calls int32
+	release := make(chan struct{})
+
+	loader := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "v", nil
+	}
+
+	const n = 20
+	results := make(chan interface{}, n)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			v, _ := c.GetOrLoad("k", time.Minute, loader)
+			results <- v
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < n; i++ {
+		if v := <-results; v != "v" {
+			t.Fatalf("GetOrLoad = %v, want \"v\"", v)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("loader called %d times across %d concurrent misses, want 1", calls, n)
+	}
+}
+
+func TestGetOrRefreshServesStaleThenRefreshes(t *testing.T) {
+	c := NewCache(0, 0, 0)
+	c.Set("k", "stale", time.Millisecond)
+
+	time.Sleep(5 * time.Millisecond)
+
+	refreshed := make(chan struct{})
+
+	loader := func() (interface{}, error) {
+		close(refreshed)
+		return "fresh", nil
+	}
+
+	v, err := c.GetOrRefresh("k", time.Minute, time.Second, loader)
+	if err != nil || v != "stale" {
+		t.Fatalf("GetOrRefresh = (%v, %v), want (\"stale\", nil)", v, err)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("loader was not invoked by the background refresh")
+	}
+
+	// The background refresh may still be writing; poll briefly for it.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if v, err := c.Get("k"); err == nil && v == "fresh" {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("cache was never updated with the refreshed value")
+}
+
+// TestGetOrLoadPanickingLoaderDoesNotDeadlockWaiters is a regression test:
+// a panicking loader used to skip finishCall entirely, so every goroutine
+// blocked on cl.wg.Wait() for that key hung forever, and the key stayed
+// wedged in c.loaders so even later, unrelated GetOrLoad calls for it
+// would deadlock too.
+func TestGetOrLoadPanickingLoaderDoesNotDeadlockWaiters(t *testing.T) {
+	c := NewCache(0, 0, 0)
+
+	release := make(chan struct{})
+
+	loader := func() (interface{}, error) {
+		<-release
+		panic("boom")
+	}
+
+	const n = 10
+	done := make(chan struct{}, n)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			defer func() {
+				recover()
+				done <- struct{}{}
+			}()
+
+			c.GetOrLoad("k", time.Minute, loader)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("only %d/%d GetOrLoad callers returned; a panicking loader left the key wedged", i, n)
+		}
+	}
+
+	// The key must not be left wedged in c.loaders: a later call with a
+	// well-behaved loader should succeed immediately.
+	v, err := c.GetOrLoad("k", time.Minute, func() (interface{}, error) {
+		return "recovered", nil
+	})
+	if err != nil || v != "recovered" {
+		t.Fatalf("GetOrLoad after a panicking loader = (%v, %v), want (\"recovered\", nil)", v, err)
+	}
+}
+
+// TestGetOrRefreshRecordsAccess is a regression test: GetOrRefresh used to
+// read c.m directly, bypassing touchLocked and Stats bookkeeping, which
+// silently broke bounded-capacity eviction and hit/miss metrics for any
+// caller using the stale-while-revalidate path.
+func TestGetOrRefreshRecordsAccess(t *testing.T) {
+	c := NewCacheWithOptions(Options{Policy: PolicyLRU, MaxEntries: 2})
+
+	loader := func() (interface{}, error) { return "v", nil }
+
+	c.Set("a", "v", 0)
+	c.Set("b", "v", 0)
+
+	for i := 0; i < 20; i++ {
+		if _, err := c.GetOrRefresh("a", time.Minute, time.Minute, loader); err != nil {
+			t.Fatalf("GetOrRefresh(a): unexpected error %v", err)
+		}
+	}
+
+	c.Set("c", "v", 0)
+
+	if !c.IsExist("a") {
+		t.Fatal("\"a\" was evicted despite being repeatedly accessed via GetOrRefresh")
+	}
+
+	if c.Stats().Hits == 0 {
+		t.Fatal("Stats().Hits stayed 0 after repeated GetOrRefresh hits")
+	}
+}
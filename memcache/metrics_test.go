@@ -0,0 +1,86 @@
+package memcache
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStatsCountsOperations(t *testing.T) {
+	c := NewCache(0, 0, 0)
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get(a): unexpected error %v", err)
+	}
+
+	if _, err := c.Get("missing"); err == nil {
+		t.Fatal("Get(missing) unexpectedly succeeded")
+	}
+
+	if err := c.Delete("a"); err != nil {
+		t.Fatalf("Delete(a): unexpected error %v", err)
+	}
+
+	s := c.Stats()
+
+	if s.Sets != 2 {
+		t.Errorf("Sets = %d, want 2", s.Sets)
+	}
+
+	if s.Hits != 1 {
+		t.Errorf("Hits = %d, want 1", s.Hits)
+	}
+
+	if s.Misses != 1 {
+		t.Errorf("Misses = %d, want 1", s.Misses)
+	}
+
+	if s.Deletes != 1 {
+		t.Errorf("Deletes = %d, want 1", s.Deletes)
+	}
+
+	if s.Size != 1 {
+		t.Errorf("Size = %d, want 1", s.Size)
+	}
+}
+
+func TestStatsCountsBoundedEvictions(t *testing.T) {
+	c := NewCacheWithOptions(Options{Policy: PolicyLRU, MaxEntries: 1})
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+
+	if got := c.Stats().Evictions; got != 1 {
+		t.Fatalf("Evictions = %d, want 1", got)
+	}
+}
+
+func TestMetricsHandlerRendersPrometheusFormat(t *testing.T) {
+	c := NewCache(0, 0, 0)
+	c.Set("a", 1, 0)
+
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get(a): unexpected error %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	c.MetricsHandler().ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		"# TYPE memcache_hits_total counter",
+		"memcache_hits_total 1",
+		"# TYPE memcache_size gauge",
+		"memcache_size 1",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("MetricsHandler output missing %q, got:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package memcache
+
+import (
+	"encoding/gob"
+	"io"
+	"os"
+	"time"
+)
+
+// RegisterType registers a concrete type with encoding/gob so that it can
+// be encoded and decoded as part of a Value's interface{} payload. Call it
+// once per concrete type you store in the cache, before the first Save or
+// Load of that type.
+func RegisterType(v interface{}) {
+	gob.Register(v)
+}
+
+// Save writes a snapshot of the cache's current contents to w via
+// encoding/gob. For Cache and other interface{}-valued instantiations,
+// concrete types held in Value.Value must be registered with
+// RegisterType beforehand, or gob will fail to encode them.
+func (c *TypedCache[K, V]) Save(w io.Writer) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return gob.NewEncoder(w).Encode(c.m)
+}
+
+// SaveFile saves the cache's contents to the file at path, creating or
+// truncating it as needed.
+func (c *TypedCache[K, V]) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Save(f)
+}
+
+// Load replaces the cache's contents with a snapshot previously written by
+// Save. Entries that are already expired are skipped. For Cache and other
+// interface{}-valued instantiations, concrete types held in Value.Value
+// must be registered with RegisterType beforehand, or gob will fail to
+// decode them.
+func (c *TypedCache[K, V]) Load(r io.Reader) error {
+	m := make(map[K]Entry[V])
+
+	if err := gob.NewDecoder(r).Decode(&m); err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.m = make(map[K]Entry[V], len(m))
+
+	switch c.policy {
+	case PolicyLRU:
+		c.lru = newLRUList[K]()
+	case PolicyLFU:
+		c.lfu = newLFUList[K]()
+	}
+
+	for k, v := range m {
+		if v.Expiration > 0 && now > v.Expiration {
+			continue
+		}
+
+		c.m[k] = v
+		c.touchLocked(k)
+	}
+
+	return nil
+}
+
+// LoadFile loads the cache's contents from the file at path. See Load.
+func (c *TypedCache[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Load(f)
+}
@@ -0,0 +1,117 @@
+package memcache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	src := NewCache(0, 0, 0)
+	src.Set("a", "1", 0)
+	src.Set("b", "2", 0)
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	dst := NewCache(0, 0, 0)
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load: unexpected error %v", err)
+	}
+
+	for _, k := range []string{"a", "b"} {
+		v, err := dst.Get(k)
+		if err != nil {
+			t.Fatalf("Get(%q): unexpected error %v", k, err)
+		}
+
+		if v != src.m[k].Value {
+			t.Fatalf("Get(%q) = %v, want %v", k, v, src.m[k].Value)
+		}
+	}
+}
+
+// TestLoadReplacesExistingContents verifies that Load matches its own doc
+// comment ("replaces the cache's contents") rather than merging: keys
+// already present but absent from the snapshot must not survive.
+func TestLoadReplacesExistingContents(t *testing.T) {
+	c := NewCache(0, 0, 0)
+	c.Set("stale", "v", 0)
+
+	var buf bytes.Buffer
+	snapshot := NewCache(0, 0, 0)
+	snapshot.Set("fresh", "v", 0)
+
+	if err := snapshot.Save(&buf); err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	if err := c.Load(&buf); err != nil {
+		t.Fatalf("Load: unexpected error %v", err)
+	}
+
+	if c.IsExist("stale") {
+		t.Fatal("Load left a pre-existing key in place; Load must replace, not merge")
+	}
+
+	if !c.IsExist("fresh") {
+		t.Fatal("Load did not restore the snapshot's key")
+	}
+}
+
+func TestLoadSkipsExpiredEntries(t *testing.T) {
+	src := NewCache(0, time.Millisecond, 0)
+	src.Set("expired", "v", 0)
+
+	time.Sleep(10 * time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	dst := NewCache(0, 0, 0)
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load: unexpected error %v", err)
+	}
+
+	if dst.IsExist("expired") {
+		t.Fatal("Load restored an already-expired entry")
+	}
+}
+
+// TestLoadSeedsEvictionPolicy is a regression test: entries restored by
+// Load on a bounded LRU cache must be visible to the eviction policy.
+// Load itself doesn't trim an oversized snapshot down to MaxEntries (it
+// is a restore, not a Set loop), but once loaded, every further Set must
+// still evict exactly as it would for entries it inserted itself -
+// before this fix, an untouched loaded entry made evictionVictimLocked
+// report no victim at all, so the cache grew without bound instead of
+// holding steady.
+func TestLoadSeedsEvictionPolicy(t *testing.T) {
+	src := NewCache(0, 0, 0)
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		src.Set(k, k, 0)
+	}
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	dst := NewCacheWithOptions(Options{Policy: PolicyLRU, MaxEntries: 3})
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load: unexpected error %v", err)
+	}
+
+	before := dst.Stats().Size
+
+	dst.Set("f", "f", 0)
+	dst.Set("g", "g", 0)
+
+	if got := dst.Stats().Size; got > before {
+		t.Fatalf("cache size grew from %d to %d after Load+Set; loaded entries are invisible to the eviction policy", before, got)
+	}
+}
@@ -0,0 +1,209 @@
+package memcache
+
+import (
+	"container/heap"
+	"container/list"
+	"time"
+)
+
+// CachePolicy selects the admission/eviction policy used once a Cache
+// reaches its Options.MaxEntries limit.
+type CachePolicy int
+
+const (
+	// PolicyNone leaves the cache unbounded: entries are only removed by
+	// Delete or by expiring, as before.
+	PolicyNone CachePolicy = iota
+	// PolicyLRU evicts the least-recently-used entry on overflow.
+	PolicyLRU
+	// PolicyLFU evicts the least-frequently-used entry on overflow.
+	PolicyLFU
+)
+
+// Options configures a Cache created via NewCacheWithOptions or a
+// TypedCache created via NewTypedCacheWithOptions.
+type Options struct {
+	Size            int
+	Expiration      time.Duration
+	CleanupInterval time.Duration
+
+	// Policy selects the eviction policy used once MaxEntries is reached.
+	// The zero value, PolicyNone, keeps the cache unbounded.
+	Policy CachePolicy
+	// MaxEntries caps the number of entries the cache holds once Policy
+	// is PolicyLRU or PolicyLFU. A value <= 0 leaves the cache unbounded.
+	MaxEntries int
+}
+
+// NewTypedCacheWithOptions creates a cache container the same way
+// NewTypedCache does, additionally enabling a bounded-capacity admission
+// policy when opts.Policy is PolicyLRU or PolicyLFU.
+func NewTypedCacheWithOptions[K comparable, V any](opts Options) *TypedCache[K, V] {
+	c := NewTypedCache[K, V](opts.Size, opts.Expiration, opts.CleanupInterval)
+
+	c.policy = opts.Policy
+	c.maxEntries = opts.MaxEntries
+
+	switch opts.Policy {
+	case PolicyLRU:
+		c.lru = newLRUList[K]()
+	case PolicyLFU:
+		c.lfu = newLFUList[K]()
+	}
+
+	return c
+}
+
+// NewCacheWithOptions creates a cache container the same way NewCache
+// does, additionally enabling a bounded-capacity admission policy when
+// opts.Policy is PolicyLRU or PolicyLFU.
+func NewCacheWithOptions(opts Options) *Cache {
+	return NewTypedCacheWithOptions[string, interface{}](opts)
+}
+
+// lruList tracks recency of use via a doubly linked list, most-recently
+// used at the front.
+type lruList[K comparable] struct {
+	l     *list.List
+	elems map[K]*list.Element
+}
+
+func newLRUList[K comparable]() *lruList[K] {
+	return &lruList[K]{
+		l:     list.New(),
+		elems: make(map[K]*list.Element),
+	}
+}
+
+func (r *lruList[K]) touch(key K) {
+	if e, ok := r.elems[key]; ok {
+		r.l.MoveToFront(e)
+		return
+	}
+
+	r.elems[key] = r.l.PushFront(key)
+}
+
+func (r *lruList[K]) remove(key K) {
+	if e, ok := r.elems[key]; ok {
+		r.l.Remove(e)
+		delete(r.elems, key)
+	}
+}
+
+func (r *lruList[K]) victim() (key K, ok bool) {
+	e := r.l.Back()
+	if e == nil {
+		return key, false
+	}
+
+	return e.Value.(K), true
+}
+
+// lfuItem is one entry in the frequency min-heap.
+type lfuItem[K comparable] struct {
+	key   K
+	freq  int
+	index int
+}
+
+// lfuHeap is a container/heap min-heap ordered by lfuItem.freq.
+type lfuHeap[K comparable] []*lfuItem[K]
+
+func (h lfuHeap[K]) Len() int           { return len(h) }
+func (h lfuHeap[K]) Less(i, j int) bool { return h[i].freq < h[j].freq }
+func (h lfuHeap[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i]; h[i].index = i; h[j].index = j }
+func (h *lfuHeap[K]) Push(x interface{}) {
+	item := x.(*lfuItem[K])
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *lfuHeap[K]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+
+	return item
+}
+
+// lfuList tracks use frequency via a min-heap plus a key index.
+type lfuList[K comparable] struct {
+	h     lfuHeap[K]
+	items map[K]*lfuItem[K]
+}
+
+func newLFUList[K comparable]() *lfuList[K] {
+	return &lfuList[K]{items: make(map[K]*lfuItem[K])}
+}
+
+func (f *lfuList[K]) touch(key K) {
+	if item, ok := f.items[key]; ok {
+		item.freq++
+		heap.Fix(&f.h, item.index)
+
+		return
+	}
+
+	item := &lfuItem[K]{key: key, freq: 1}
+	f.items[key] = item
+	heap.Push(&f.h, item)
+}
+
+func (f *lfuList[K]) remove(key K) {
+	item, ok := f.items[key]
+	if !ok {
+		return
+	}
+
+	heap.Remove(&f.h, item.index)
+	delete(f.items, key)
+}
+
+func (f *lfuList[K]) victim() (key K, ok bool) {
+	if f.h.Len() == 0 {
+		return key, false
+	}
+
+	return f.h[0].key, true
+}
+
+// touchLocked records an access to key for the active policy. It must be
+// called with c.mutex held for writing.
+func (c *TypedCache[K, V]) touchLocked(key K) {
+	switch c.policy {
+	case PolicyLRU:
+		c.lru.touch(key)
+	case PolicyLFU:
+		c.lfu.touch(key)
+	}
+}
+
+// removeLocked drops key from the active policy's bookkeeping structures
+// and deletes its entry from c.m. It must be called with c.mutex held for
+// writing.
+func (c *TypedCache[K, V]) removeLocked(key K) {
+	switch c.policy {
+	case PolicyLRU:
+		c.lru.remove(key)
+	case PolicyLFU:
+		c.lfu.remove(key)
+	}
+
+	delete(c.m, key)
+}
+
+// evictionVictimLocked picks the key the active policy would evict to make
+// room for a new entry. It must be called with c.mutex held for writing.
+func (c *TypedCache[K, V]) evictionVictimLocked() (key K, ok bool) {
+	switch c.policy {
+	case PolicyLRU:
+		return c.lru.victim()
+	case PolicyLFU:
+		return c.lfu.victim()
+	default:
+		return key, false
+	}
+}
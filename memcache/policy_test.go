@@ -0,0 +1,114 @@
+package memcache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewCacheWithOptions(Options{Policy: PolicyLRU, MaxEntries: 2})
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get(a): unexpected error %v", err)
+	}
+
+	c.Set("c", 3, 0)
+
+	if c.IsExist("b") {
+		t.Fatal("LRU evicted the wrong entry: \"b\" should have been the victim")
+	}
+
+	if !c.IsExist("a") || !c.IsExist("c") {
+		t.Fatal("LRU evicted an entry that was still in use")
+	}
+}
+
+func TestLFUEvictsLeastFrequentlyUsed(t *testing.T) {
+	c := NewCacheWithOptions(Options{Policy: PolicyLFU, MaxEntries: 2})
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+
+	// Access "a" repeatedly so "b" becomes the least-frequently-used entry.
+	for i := 0; i < 3; i++ {
+		if _, err := c.Get("a"); err != nil {
+			t.Fatalf("Get(a): unexpected error %v", err)
+		}
+	}
+
+	c.Set("c", 3, 0)
+
+	if c.IsExist("b") {
+		t.Fatal("LFU evicted the wrong entry: \"b\" should have been the victim")
+	}
+
+	if !c.IsExist("a") || !c.IsExist("c") {
+		t.Fatal("LFU evicted an entry that was still in use")
+	}
+}
+
+func TestBoundedCacheEvictionFiresOnEvicted(t *testing.T) {
+	c := NewCacheWithOptions(Options{Policy: PolicyLRU, MaxEntries: 1})
+
+	var evictedKey string
+
+	c.OnEvicted(func(key string, value interface{}) {
+		evictedKey = key
+	})
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+
+	if evictedKey != "a" {
+		t.Fatalf("OnEvicted fired for %q, want \"a\"", evictedKey)
+	}
+}
+
+func TestPolicyNoneIsUnbounded(t *testing.T) {
+	c := NewCache(0, 0, 0)
+
+	for i := 0; i < 1000; i++ {
+		c.Set(string(rune(i)), i, 0)
+	}
+
+	if got := c.Stats().Size; got != 1000 {
+		t.Fatalf("unbounded cache size = %d, want 1000", got)
+	}
+}
+
+// TestFlushAllConcurrentWithSet is a regression test for a data race:
+// FlushAll used to reassign c.m/c.lru/c.lfu with no lock held, racing
+// with Set's reads/writes of the same fields on a policy-enabled cache.
+func TestFlushAllConcurrentWithSet(t *testing.T) {
+	c := NewCacheWithOptions(Options{Policy: PolicyLRU, MaxEntries: 10})
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				c.Set(string(rune('a'+i%26)), i, 0)
+			}
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		c.FlushAll()
+		time.Sleep(time.Millisecond)
+	}
+
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,156 @@
+package memcache
+
+import (
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ShardedCache splits the keyspace across N independent Cache shards, each
+// with its own map and mutex, so that concurrent Set/Delete traffic on
+// different keys does not contend on a single lock. It has Cache's Set,
+// Get, GetMulti, Delete, IsExist, Expire, FlushAll, OnEvicted, GetOrLoad,
+// GetOrRefresh, Stats and MetricsHandler methods, so it can replace a Cache
+// that uses only those; it has no Save, SaveFile, Load or LoadFile.
+type ShardedCache struct {
+	shards []*Cache
+	num    uint32
+}
+
+// NewShardedCache creates a sharded cache container with the given number
+// of shards. Each shard is its own Cache and starts gc automatically, same
+// as NewCache.
+func NewShardedCache(shards, size int, expiration, cleanupInterval time.Duration) *ShardedCache {
+	if shards <= 0 {
+		shards = 1
+	}
+
+	cs := make([]*Cache, shards)
+	for i := range cs {
+		cs[i] = NewCache(size, expiration, cleanupInterval)
+	}
+
+	return &ShardedCache{
+		shards: cs,
+		num:    uint32(shards),
+	}
+}
+
+// shardFor returns the shard responsible for key, chosen via FNV-1a.
+func (sc *ShardedCache) shardFor(key string) *Cache {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+
+	return sc.shards[h.Sum32()%sc.num]
+}
+
+// OnEvicted registers f on every shard. See Cache.OnEvicted.
+func (sc *ShardedCache) OnEvicted(f func(key string, value interface{})) {
+	for _, shard := range sc.shards {
+		shard.OnEvicted(f)
+	}
+}
+
+// Set cache by key with duration.
+func (sc *ShardedCache) Set(key string, value interface{}, duration time.Duration) {
+	sc.shardFor(key).Set(key, value, duration)
+}
+
+// Get get cached value by key.
+func (sc *ShardedCache) Get(key string) (value interface{}, err error) {
+	return sc.shardFor(key).Get(key)
+}
+
+// GetMulti gets caches from memory.
+// if non-existed or expired, return nil.
+func (sc *ShardedCache) GetMulti(keys []string) []interface{} {
+	rc := make([]interface{}, len(keys))
+
+	var wg sync.WaitGroup
+
+	wg.Add(len(keys))
+
+	for i, key := range keys {
+		go func(i int, key string) {
+			defer wg.Done()
+
+			v, _ := sc.Get(key)
+			rc[i] = v
+		}(i, key)
+	}
+
+	wg.Wait()
+
+	return rc
+}
+
+// Delete remove cache by key.
+func (sc *ShardedCache) Delete(key string) error {
+	return sc.shardFor(key).Delete(key)
+}
+
+// Exist check if cached value exists or not.
+func (sc *ShardedCache) IsExist(key string) bool {
+	return sc.shardFor(key).IsExist(key)
+}
+
+// Expire check if cached value expired or not.
+// if cache expire == true, cache not expire == false.
+func (sc *ShardedCache) Expire(key string) (bool, error) {
+	return sc.shardFor(key).Expire(key)
+}
+
+// GetOrLoad delegates to the shard responsible for key. See Cache.GetOrLoad.
+func (sc *ShardedCache) GetOrLoad(key string, ttl time.Duration, loader func() (interface{}, error)) (interface{}, error) {
+	return sc.shardFor(key).GetOrLoad(key, ttl, loader)
+}
+
+// GetOrRefresh delegates to the shard responsible for key. See Cache.GetOrRefresh.
+func (sc *ShardedCache) GetOrRefresh(key string, ttl, staleGrace time.Duration, loader func() (interface{}, error)) (interface{}, error) {
+	return sc.shardFor(key).GetOrRefresh(key, ttl, staleGrace, loader)
+}
+
+// Stats returns the sum of every shard's Stats.
+func (sc *ShardedCache) Stats() Stats {
+	var total Stats
+
+	for _, shard := range sc.shards {
+		s := shard.Stats()
+
+		total.Hits += s.Hits
+		total.Misses += s.Misses
+		total.Evictions += s.Evictions
+		total.Expirations += s.Expirations
+		total.Sets += s.Sets
+		total.Deletes += s.Deletes
+		total.Size += s.Size
+	}
+
+	return total
+}
+
+// MetricsHandler returns an http.Handler that renders sc's aggregate
+// Stats in Prometheus text exposition format. See Cache.MetricsHandler.
+func (sc *ShardedCache) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeStats(w, sc.Stats())
+	})
+}
+
+// FlushAll clear all cache, across every shard in parallel.
+func (sc *ShardedCache) FlushAll() {
+	var wg sync.WaitGroup
+
+	wg.Add(len(sc.shards))
+
+	for _, shard := range sc.shards {
+		go func(shard *Cache) {
+			defer wg.Done()
+
+			shard.FlushAll()
+		}(shard)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,103 @@
+package memcache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardedCacheSetGet(t *testing.T) {
+	sc := NewShardedCache(4, 0, time.Minute, 0)
+
+	for i := 0; i < 100; i++ {
+		sc.Set(strconv.Itoa(i), i, 0)
+	}
+
+	for i := 0; i < 100; i++ {
+		v, err := sc.Get(strconv.Itoa(i))
+		if err != nil {
+			t.Fatalf("Get(%d): unexpected error %v", i, err)
+		}
+
+		if v.(int) != i {
+			t.Fatalf("Get(%d) = %v, want %d", i, v, i)
+		}
+	}
+}
+
+func TestShardedCacheDelete(t *testing.T) {
+	sc := NewShardedCache(4, 0, time.Minute, 0)
+
+	sc.Set("k", "v", 0)
+
+	if err := sc.Delete("k"); err != nil {
+		t.Fatalf("Delete: unexpected error %v", err)
+	}
+
+	if _, err := sc.Get("k"); err != ErrKeyNotFound {
+		t.Fatalf("Get after Delete = %v, want ErrKeyNotFound", err)
+	}
+
+	if err := sc.Delete("missing"); err != ErrKeyNotFound {
+		t.Fatalf("Delete(missing) = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestShardedCacheOnEvicted(t *testing.T) {
+	sc := NewShardedCache(4, 0, time.Minute, 0)
+
+	var mu sync.Mutex
+	evicted := make(map[string]interface{})
+
+	sc.OnEvicted(func(key string, value interface{}) {
+		mu.Lock()
+		evicted[key] = value
+		mu.Unlock()
+	})
+
+	for i := 0; i < 20; i++ {
+		sc.Set(strconv.Itoa(i), i, 0)
+	}
+
+	for i := 0; i < 20; i++ {
+		_ = sc.Delete(strconv.Itoa(i))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(evicted) != 20 {
+		t.Fatalf("got %d evictions across shards, want 20", len(evicted))
+	}
+}
+
+// TestShardedCacheConcurrent exercises concurrent Set/Get/Delete across
+// many keys so shard-local locking bugs show up under go test -race.
+func TestShardedCacheConcurrent(t *testing.T) {
+	sc := NewShardedCache(8, 0, time.Minute, 0)
+
+	var wg sync.WaitGroup
+
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				key := strconv.Itoa(g*1000 + i)
+
+				sc.Set(key, i, 0)
+				sc.Get(key)
+				sc.IsExist(key)
+
+				if i%10 == 0 {
+					sc.Delete(key)
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,72 @@
+package memcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTypedCacheInt64Key(t *testing.T) {
+	c := NewTypedCache[int64, string](0, 0, 0)
+
+	c.Set(42, "answer", 0)
+
+	v, err := c.Get(42)
+	if err != nil || v != "answer" {
+		t.Fatalf("Get(42) = (%q, %v), want (\"answer\", nil)", v, err)
+	}
+
+	if _, err := c.Get(7); err != ErrKeyNotFound {
+		t.Fatalf("Get(7) = %v, want ErrKeyNotFound", err)
+	}
+}
+
+type userID struct {
+	tenant string
+	id     int
+}
+
+func TestTypedCacheStructKey(t *testing.T) {
+	c := NewTypedCache[userID, int](0, 0, 0)
+
+	k := userID{tenant: "acme", id: 1}
+
+	c.Set(k, 100, 0)
+
+	v, err := c.Get(k)
+	if err != nil || v != 100 {
+		t.Fatalf("Get(%+v) = (%d, %v), want (100, nil)", k, v, err)
+	}
+
+	if c.IsExist(userID{tenant: "acme", id: 2}) {
+		t.Fatal("IsExist reported a key that was never set")
+	}
+}
+
+func TestTypedCacheWithOptionsBoundedCapacity(t *testing.T) {
+	c := NewTypedCacheWithOptions[int, string](Options{Policy: PolicyLRU, MaxEntries: 1})
+
+	c.Set(1, "a", 0)
+	c.Set(2, "b", 0)
+
+	if c.IsExist(1) {
+		t.Fatal("LRU did not evict key 1 once MaxEntries was exceeded")
+	}
+
+	if !c.IsExist(2) {
+		t.Fatal("the most recently set key should still be present")
+	}
+}
+
+// TestCacheIsTypedCacheStringInterfaceAlias exercises the original Cache
+// API to confirm it still behaves as a plain interface{}-valued,
+// string-keyed cache after the generic redesign.
+func TestCacheIsTypedCacheStringInterfaceAlias(t *testing.T) {
+	var c *Cache = NewTypedCache[string, interface{}](0, time.Minute, 0)
+
+	c.Set("k", 7, 0)
+
+	v, err := c.Get("k")
+	if err != nil || v.(int) != 7 {
+		t.Fatalf("Get(k) = (%v, %v), want (7, nil)", v, err)
+	}
+}